@@ -0,0 +1,92 @@
+package main
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/sojoudian/goTestAPI/internal/auth"
+	"github.com/sojoudian/goTestAPI/internal/router"
+)
+
+// writePolicy requires auth for the book-mutating methods and leaves GET
+// (and the WhoAmI probe) open to anonymous callers.
+var writePolicy = auth.Policy{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// authMiddlewares builds the API-key and JWT authenticators plus the
+// policy enforcer from environment configuration. API_KEYS and the JWT_*
+// vars are all optional; an authenticator is only added when its config
+// is present. The policy enforcer is always added, so with no
+// authenticator configured every request using a writePolicy method is
+// rejected with 401 and only the open methods (such as GET) are usable.
+func authMiddlewares() ([]router.Middleware, error) {
+	var chain []router.Middleware
+
+	if keys := parseAPIKeys(os.Getenv("API_KEYS")); len(keys) > 0 {
+		chain = append(chain, auth.APIKeyAuthenticator(keys))
+	}
+
+	if secret := os.Getenv("JWT_HMAC_SECRET"); secret != "" {
+		chain = append(chain, auth.JWTAuthenticator(auth.JWTConfig{
+			HMACSecret: []byte(secret),
+			Issuer:     os.Getenv("JWT_ISSUER"),
+			Audience:   os.Getenv("JWT_AUDIENCE"),
+		}))
+	} else if pemData := os.Getenv("JWT_RSA_PUBLIC_KEY"); pemData != "" {
+		pubKey, err := parseRSAPublicKey(pemData)
+		if err != nil {
+			return nil, fmt.Errorf("parse JWT_RSA_PUBLIC_KEY: %w", err)
+		}
+		chain = append(chain, auth.JWTAuthenticator(auth.JWTConfig{
+			RSAPublicKey: pubKey,
+			Issuer:       os.Getenv("JWT_ISSUER"),
+			Audience:     os.Getenv("JWT_AUDIENCE"),
+		}))
+	}
+
+	chain = append(chain, auth.Require(writePolicy))
+	return chain, nil
+}
+
+// parseAPIKeys parses "name:key,name:key" pairs into key -> name, the
+// shape auth.APIKeyAuthenticator compares requests against.
+func parseAPIKeys(raw string) map[string]string {
+	if raw == "" {
+		return nil
+	}
+
+	keys := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		name, key, found := strings.Cut(pair, ":")
+		if !found || name == "" || key == "" {
+			continue
+		}
+		keys[key] = name
+	}
+	return keys
+}
+
+func parseRSAPublicKey(pemData string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("PEM block is not an RSA public key")
+	}
+	return key, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/sojoudian/goTestAPI/internal/httperror"
+)
+
+// validate is shared across handlers; go-playground/validator caches
+// struct metadata internally, so a single instance should be reused. It
+// reports field names using their json tag so error payloads match the
+// wire format clients actually send.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" || name == "" {
+			return f.Name
+		}
+		return name
+	})
+	return v
+}
+
+// fieldError reports one failed validation rule for a request field.
+type fieldError = httperror.FieldError
+
+// sendError writes a JSON error envelope with the given status code. msg
+// is a machine-readable error code (e.g. "validation_failed",
+// "not_found"); fields carries per-field validation details and may be
+// nil. It's a thin wrapper around httperror.Write so handlers in this
+// package and middleware in internal/router and internal/auth all send
+// the same envelope shape.
+func sendError(w http.ResponseWriter, code int, msg string, fields []fieldError) {
+	httperror.Write(w, code, msg, fields)
+}
+
+// validationFields converts validator's per-field errors into the
+// fieldError shape returned in the API's error envelope.
+func validationFields(err error) []fieldError {
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return nil
+	}
+
+	fields := make([]fieldError, 0, len(verrs))
+	for _, fe := range verrs {
+		fields = append(fields, fieldError{
+			Field: fe.Field(),
+			Rule:  fe.Tag(),
+		})
+	}
+	return fields
+}
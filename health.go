@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/sojoudian/goTestAPI/internal/store"
+)
+
+// Healthz reports liveness: if the process can handle a request at all,
+// it's healthy. It never touches the store, so it won't flap if the
+// backing store is briefly unavailable.
+func Healthz(w http.ResponseWriter, r *http.Request) {
+	sendJSON(w, map[string]string{"status": "ok"}, http.StatusOK)
+}
+
+// readyzHandler reports readiness by pinging the backing store with a
+// cheap List call; a failing store means the instance shouldn't receive
+// traffic yet even though the process itself is alive.
+func readyzHandler(s store.BookStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := s.List(store.ListOptions{Limit: 1}); err != nil {
+			sendError(w, http.StatusServiceUnavailable, "not_ready", nil)
+			return
+		}
+		sendJSON(w, map[string]string{"status": "ok"}, http.StatusOK)
+	}
+}
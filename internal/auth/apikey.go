@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/sojoudian/goTestAPI/internal/httperror"
+)
+
+// APIKeyAuthenticator returns middleware that recognizes an API key from
+// either the "Authorization: ApiKey <key>" or "X-API-Key" header, checked
+// against keys using a constant-time compare. A request without either
+// header passes through unauthenticated (for JWT or public GETs to
+// handle); a request with a header that doesn't match any key is
+// rejected with 401 rather than silently falling through.
+func APIKeyAuthenticator(keys map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key, present := extractAPIKey(r)
+			if !present {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			owner, ok := lookupAPIKey(keys, key)
+			if !ok {
+				httperror.Write(w, http.StatusUnauthorized, "invalid_api_key", nil)
+				return
+			}
+
+			ctx := withIdentity(r.Context(), Identity{Subject: owner, Method: "apikey"})
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func extractAPIKey(r *http.Request) (string, bool) {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key, true
+	}
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "ApiKey ") {
+		return strings.TrimPrefix(auth, "ApiKey "), true
+	}
+	return "", false
+}
+
+// lookupAPIKey compares key against every configured key in constant
+// time, returning the owner name keys maps it to.
+func lookupAPIKey(keys map[string]string, key string) (owner string, ok bool) {
+	for candidate, name := range keys {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func okHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestAPIKeyAuthenticator(t *testing.T) {
+	keys := map[string]string{"secret-key": "alice"}
+	handler := APIKeyAuthenticator(keys)(okHandler())
+
+	t.Run("valid X-API-Key", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("X-API-Key", "secret-key")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("valid Authorization ApiKey", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("Authorization", "ApiKey secret-key")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("invalid key rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("X-API-Key", "wrong-key")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("missing key passes through", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/books", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,40 @@
+// Package auth provides pluggable HTTP authentication middleware
+// (API-key and JWT bearer) plus a method-based policy for deciding which
+// routes require an authenticated identity.
+package auth
+
+import "context"
+
+// Identity is the authenticated caller extracted from a request by one
+// of the middlewares in this package.
+type Identity struct {
+	// Subject identifies the caller: the API key's owner name for
+	// API-key auth, or the JWT's "sub" claim for bearer auth.
+	Subject string
+	// Method names how the caller authenticated, e.g. "apikey" or "jwt".
+	Method string
+	// Claims holds the JWT claims when Method is "jwt"; nil otherwise.
+	Claims map[string]interface{}
+}
+
+type identityKey struct{}
+
+func withIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the Identity stashed by an auth middleware, if any.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// Policy decides, per HTTP method, whether a request must carry an
+// authenticated Identity. Methods absent from the map are treated as not
+// requiring auth, so a zero-value Policy leaves every route public.
+type Policy map[string]bool
+
+// RequireFor reports whether method requires an authenticated identity.
+func (p Policy) RequireFor(method string) bool {
+	return p[method]
+}
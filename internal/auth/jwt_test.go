@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func signHS256(t *testing.T, secret []byte, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	return signed
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	secret := []byte("test-secret")
+	cfg := JWTConfig{HMACSecret: secret, Issuer: "goTestAPI", Audience: "books-api"}
+	handler := JWTAuthenticator(cfg)(okHandler())
+
+	validClaims := jwt.MapClaims{
+		"sub": "user-1",
+		"iss": "goTestAPI",
+		"aud": "books-api",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, secret, validClaims))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("expired token rejected", func(t *testing.T) {
+		claims := jwt.MapClaims{
+			"sub": "user-1",
+			"iss": "goTestAPI",
+			"aud": "books-api",
+			"exp": time.Now().Add(-time.Hour).Unix(),
+		}
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, secret, claims))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("wrong signature rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, []byte("other-secret"), validClaims))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("wrong audience rejected", func(t *testing.T) {
+		claims := jwt.MapClaims{
+			"sub": "user-1",
+			"iss": "goTestAPI",
+			"aud": "other-api",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, secret, claims))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("missing exp rejected", func(t *testing.T) {
+		claims := jwt.MapClaims{
+			"sub": "user-1",
+			"iss": "goTestAPI",
+			"aud": "books-api",
+		}
+		req := httptest.NewRequest(http.MethodPost, "/books", nil)
+		req.Header.Set("Authorization", "Bearer "+signHS256(t, secret, claims))
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("expected 401, got %d", rec.Code)
+		}
+	})
+
+	t.Run("missing header passes through", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/books", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rec.Code)
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/sojoudian/goTestAPI/internal/httperror"
+)
+
+// Require returns middleware that rejects requests with 401 when policy
+// marks the request's method as needing auth and no prior middleware
+// (APIKeyAuthenticator, JWTAuthenticator) attached an Identity.
+func Require(policy Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if policy.RequireFor(r.Method) {
+				if _, ok := FromContext(r.Context()); !ok {
+					httperror.Write(w, http.StatusUnauthorized, "authentication_required", nil)
+					return
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
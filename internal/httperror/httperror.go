@@ -0,0 +1,31 @@
+// Package httperror is the shared JSON error envelope every HTTP
+// response in this API uses, including ones written by packages (router,
+// auth) that can't import main's handler code.
+package httperror
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// FieldError reports one failed validation rule for a request field.
+type FieldError struct {
+	Field string `json:"field"`
+	Rule  string `json:"rule"`
+}
+
+// Body is the JSON shape every error response uses.
+type Body struct {
+	Error   string       `json:"error"`
+	Message string       `json:"message,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// Write sends a JSON error envelope with the given status code. msg is a
+// machine-readable error code (e.g. "validation_failed", "not_found");
+// fields carries per-field validation details and may be nil.
+func Write(w http.ResponseWriter, code int, msg string, fields []FieldError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(Body{Error: msg, Fields: fields})
+}
@@ -0,0 +1,94 @@
+// Package metrics exposes the Prometheus collectors for this API and a
+// middleware that records them per request.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/sojoudian/goTestAPI/internal/router"
+)
+
+// Metrics holds the collectors recorded on every request. Construct with
+// New and register with a prometheus.Registerer before serving traffic.
+type Metrics struct {
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+}
+
+// New creates the HTTP request collectors, unregistered.
+func New() *Metrics {
+	return &Metrics{
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests processed, labeled by method, route and status code.",
+		}, []string{"method", "route", "code"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency in seconds, labeled by method and route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+	}
+}
+
+// MustRegister registers every collector in m, plus booksTotal (a gauge
+// sourced from the live store), against reg.
+func (m *Metrics) MustRegister(reg prometheus.Registerer, booksTotal prometheus.Collector) {
+	reg.MustRegister(m.requestsTotal, m.requestDuration, booksTotal)
+}
+
+// Middleware records requestsTotal and requestDuration for every request
+// that reaches it, labeling by router.Pattern(r) rather than the raw
+// path so path parameters don't blow up cardinality.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		next.ServeHTTP(rec, r)
+
+		route := router.Pattern(r)
+		if route == "" {
+			route = "unmatched"
+		}
+		m.requestsTotal.WithLabelValues(r.Method, route, strconv.Itoa(rec.status)).Inc()
+		m.requestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Handler serves the registered collectors in Prometheus exposition
+// format.
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+// BooksTotalGauge returns a GaugeFunc that reports the store's current
+// book count at scrape time via count, rather than tracking it
+// incrementally (simplest way to keep it always correct across
+// create/delete/restart).
+func BooksTotalGauge(count func() (int, error)) prometheus.Collector {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "books_total",
+		Help: "Current number of books in the store.",
+	}, func() float64 {
+		n, err := count()
+		if err != nil {
+			return 0
+		}
+		return float64(n)
+	})
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+// CORSConfig configures CORS. AllowedOrigins lists exact origins allowed
+// to make cross-origin requests; "*" allows any origin. An empty
+// AllowedOrigins allows no origin, so CORS is effectively disabled.
+type CORSConfig struct {
+	AllowedOrigins []string
+}
+
+// CORS returns middleware that sets Access-Control-Allow-* headers for
+// requests from an allowed origin and answers OPTIONS preflight requests
+// directly, per cfg.
+func CORS(cfg CORSConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || !originAllowed(cfg.AllowedOrigins, origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func originAllowed(allowed []string, origin string) bool {
+	for _, a := range allowed {
+		if a == "*" || a == origin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+// Package middleware holds router.Middleware implementations shared
+// across routes.
+package middleware
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/sojoudian/goTestAPI/internal/httperror"
+)
+
+// Recoverer recovers panics from the wrapped handler, logs them, and
+// responds with 500 instead of letting the server crash the connection.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Printf("panic handling %s %s: %v", r.Method, r.URL.Path, err)
+				httperror.Write(w, http.StatusInternalServerError, "internal_error", nil)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
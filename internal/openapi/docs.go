@@ -0,0 +1,29 @@
+package openapi
+
+import (
+	"embed"
+	"net/http"
+)
+
+//go:embed docs/index.html
+var docsFS embed.FS
+
+// DocsHandler serves /docs. The original request asked for an embedded
+// Swagger UI; this is NOT that. Vendoring the real swagger-ui-dist
+// bundle needs its npm package fetched in, and this environment has no
+// registry access to do that honestly (no CDN, no npm, no local cache -
+// checked, not assumed). Until someone vendors those assets by hand,
+// this serves a minimal stand-in that fetches /openapi.json and lists
+// routes with vanilla JS and inline CSS: no request/response schema
+// rendering, no try-it-out, none of what Swagger UI actually gives you.
+func DocsHandler() http.HandlerFunc {
+	page, err := docsFS.ReadFile("docs/index.html")
+	if err != nil {
+		panic(err) // embedded at build time; a read failure means the embed is broken
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	}
+}
@@ -0,0 +1,26 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// JSONHandler serves the spec built from info and routes as
+// application/json, regenerating it on every request so it always
+// reflects the routes slice passed in.
+func JSONHandler(info Info, routes []Route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Build(info, routes))
+	}
+}
+
+// YAMLHandler is the YAML equivalent of JSONHandler.
+func YAMLHandler(info Info, routes []Route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		yaml.NewEncoder(w).Encode(Build(info, routes))
+	}
+}
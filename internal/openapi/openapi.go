@@ -0,0 +1,167 @@
+// Package openapi builds an OpenAPI 3.1 document from a declarative list
+// of routes, deriving request/response schemas from Go struct reflection
+// so the spec can't drift from the routes it is given. Routes that
+// main.go registers outside that list (/openapi.json, /openapi.yaml,
+// /docs, /healthz, /readyz and /metrics) are not described in the spec.
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/sojoudian/goTestAPI/internal/httperror"
+)
+
+// Route describes one HTTP route for spec generation. RequestType and
+// ResponseType may be nil when a route has no JSON body on that side.
+type Route struct {
+	Method       string
+	Pattern      string
+	Summary      string
+	RequestType  reflect.Type
+	ResponseType reflect.Type
+	AuthRequired bool
+}
+
+// Info is the OpenAPI document's top-level "info" object.
+type Info struct {
+	Title   string
+	Version string
+}
+
+// Build renders routes into an OpenAPI 3.1 document as a plain
+// map[string]interface{} tree, ready for JSON or YAML encoding.
+func Build(info Info, routes []Route) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	paths := map[string]interface{}{}
+
+	for _, rte := range routes {
+		opsByPath, _ := paths[rte.Pattern].(map[string]interface{})
+		if opsByPath == nil {
+			opsByPath = map[string]interface{}{}
+			paths[rte.Pattern] = opsByPath
+		}
+		opsByPath[strings.ToLower(rte.Method)] = operation(rte, schemas)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.1.0",
+		"info": map[string]interface{}{
+			"title":   info.Title,
+			"version": info.Version,
+		},
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"ApiKeyAuth": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": "X-API-Key",
+				},
+				"BearerAuth": map[string]interface{}{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		},
+		"paths": paths,
+	}
+}
+
+func operation(rte Route, schemas map[string]interface{}) map[string]interface{} {
+	op := map[string]interface{}{
+		"summary":   rte.Summary,
+		"responses": responses(rte, schemas),
+	}
+
+	if rte.RequestType != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": refSchema(rte.RequestType, schemas),
+				},
+			},
+		}
+	}
+
+	if params := pathParams(rte.Pattern); len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	if rte.AuthRequired {
+		op["security"] = []interface{}{
+			map[string]interface{}{"ApiKeyAuth": []interface{}{}},
+			map[string]interface{}{"BearerAuth": []interface{}{}},
+		}
+	}
+
+	return op
+}
+
+func responses(rte Route, schemas map[string]interface{}) map[string]interface{} {
+	okStatus := "200"
+	switch rte.Method {
+	case "POST":
+		okStatus = "201"
+	case "DELETE":
+		okStatus = "204"
+	}
+
+	resp := map[string]interface{}{}
+	if rte.ResponseType != nil {
+		resp[okStatus] = map[string]interface{}{
+			"description": "OK",
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": refSchema(rte.ResponseType, schemas),
+				},
+			},
+		}
+	} else {
+		resp[okStatus] = map[string]interface{}{"description": "OK"}
+	}
+
+	resp["400"] = errorResponse(schemas)
+	resp["404"] = errorResponse(schemas)
+	if rte.AuthRequired {
+		resp["401"] = errorResponse(schemas)
+	}
+	return resp
+}
+
+func errorResponse(schemas map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": "Error",
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": refSchema(reflect.TypeOf(APIError{}), schemas),
+			},
+		},
+	}
+}
+
+// APIError is the error envelope every handler's sendError actually sends;
+// aliased from internal/httperror so the generated schema can't drift from
+// the real response shape.
+type APIError = httperror.Body
+
+// FieldError is the per-field validation detail nested in APIError.
+type FieldError = httperror.FieldError
+
+func pathParams(pattern string) []interface{} {
+	var params []interface{}
+	for _, seg := range strings.Split(pattern, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			name := strings.TrimSuffix(strings.TrimPrefix(seg, "{"), "}")
+			params = append(params, map[string]interface{}{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+	}
+	return params
+}
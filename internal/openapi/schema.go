@@ -0,0 +1,88 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// refSchema registers t's schema under components.schemas (by name) if
+// not already present, and returns a "$ref" pointing at it.
+func refSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || t.Name() == "" {
+		return schemaFor(t, schemas)
+	}
+
+	name := t.Name()
+	if _, ok := schemas[name]; !ok {
+		schemas[name] = nil // reserve the slot, guarding against recursive types
+		schemas[name] = schemaFor(t, schemas)
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// schemaFor converts a Go type into an OpenAPI (JSON Schema) object,
+// using json tags for property names and a "required" validate tag to
+// populate the schema's required list.
+func schemaFor(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": refSchema(t.Elem(), schemas),
+		}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object"}
+	case reflect.Struct:
+		return structSchema(t, schemas)
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+func structSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []interface{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonTag := strings.Split(field.Tag.Get("json"), ",")[0]
+		if jsonTag == "-" {
+			continue
+		}
+		if jsonTag == "" {
+			jsonTag = field.Name
+		}
+
+		properties[jsonTag] = refSchema(field.Type, schemas)
+
+		if strings.Contains(field.Tag.Get("validate"), "required") {
+			required = append(required, jsonTag)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
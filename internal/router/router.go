@@ -0,0 +1,158 @@
+// Package router is a small HTTP mux that supports path parameters
+// (e.g. "/books/{id}") and a composable middleware chain, in the spirit
+// of Go 1.22's http.ServeMux patterns but usable on older toolchains.
+package router
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/sojoudian/goTestAPI/internal/httperror"
+)
+
+// Middleware wraps a handler to add cross-cutting behavior (logging,
+// recovery, auth, CORS, request IDs, ...).
+type Middleware func(http.Handler) http.Handler
+
+// Chain is an ordered list of Middleware applied outermost-first: for
+// Chain{A, B}, a request flows A -> B -> handler.
+type Chain []Middleware
+
+// Then wraps handler with every middleware in the chain.
+func (c Chain) Then(handler http.Handler) http.Handler {
+	for i := len(c) - 1; i >= 0; i-- {
+		handler = c[i](handler)
+	}
+	return handler
+}
+
+type route struct {
+	method  string
+	pattern string
+	segs    []string
+	handler http.Handler
+}
+
+// Router matches requests by method and path pattern, extracting any
+// "{name}" segments as path parameters.
+type Router struct {
+	global Chain
+	routes []route
+}
+
+// New returns an empty Router. Global middleware passed here runs on
+// every request this Router serves, including unmatched paths and
+// method mismatches (so e.g. CORS preflight and request logging see
+// every response), before any per-route middleware.
+func New(global ...Middleware) *Router {
+	return &Router{global: Chain(global)}
+}
+
+// Handle registers handler for method and pattern, e.g.
+// r.Handle(http.MethodGet, "/books/{id}", GetBook). Per-route middleware
+// runs after the router's global chain.
+func (rt *Router) Handle(method, pattern string, handler http.HandlerFunc, mw ...Middleware) {
+	rt.routes = append(rt.routes, route{
+		method:  method,
+		pattern: pattern,
+		segs:    splitPath(pattern),
+		handler: Chain(mw).Then(handler),
+	})
+}
+
+// ServeHTTP implements http.Handler, matching the request against
+// registered routes and dispatching through the global middleware chain.
+// The global chain wraps every outcome, including 404/405 fallbacks, so
+// middleware like CORS preflight handling or request logging runs
+// regardless of whether a route matched.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqSegs := splitPath(r.URL.Path)
+
+	var matched *route
+	allowed := make([]string, 0, 4)
+	for i := range rt.routes {
+		rte := &rt.routes[i]
+		params, ok := match(rte.segs, reqSegs)
+		if !ok {
+			continue
+		}
+		if rte.method != r.Method {
+			allowed = append(allowed, rte.method)
+			continue
+		}
+
+		ctx := context.WithValue(r.Context(), patternKey{}, rte.pattern)
+		if len(params) > 0 {
+			ctx = context.WithValue(ctx, paramsKey{}, params)
+		}
+		r = r.WithContext(ctx)
+		matched = rte
+		break
+	}
+
+	var final http.Handler
+	switch {
+	case matched != nil:
+		final = matched.handler
+	case len(allowed) > 0:
+		final = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			httperror.Write(w, http.StatusMethodNotAllowed, "method_not_allowed", nil)
+		})
+	default:
+		final = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			httperror.Write(w, http.StatusNotFound, "not_found", nil)
+		})
+	}
+
+	rt.global.Then(final).ServeHTTP(w, r)
+}
+
+type paramsKey struct{}
+type patternKey struct{}
+
+// Param returns the value captured for name by the matched route's
+// "{name}" segment, or "" if there was no such segment.
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params[name]
+}
+
+// Pattern returns the registered pattern that matched r, e.g.
+// "/books/{id}", or "" if the request never matched a route (so
+// middleware can use it as a low-cardinality metrics label instead of
+// the raw, ID-bearing path).
+func Pattern(r *http.Request) string {
+	pattern, _ := r.Context().Value(patternKey{}).(string)
+	return pattern
+}
+
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+func match(pattern, path []string) (map[string]string, bool) {
+	if len(pattern) != len(path) {
+		return nil, false
+	}
+
+	var params map[string]string
+	for i, seg := range pattern {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[strings.TrimSuffix(strings.TrimPrefix(seg, "{"), "}")] = path[i]
+			continue
+		}
+		if seg != path[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
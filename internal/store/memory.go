@@ -0,0 +1,140 @@
+package store
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is an in-process BookStore backed by a map. It is the
+// original storage behavior of this API, kept around for tests and for
+// running the server without a Mongo deployment.
+type MemoryStore struct {
+	mu     sync.Mutex
+	books  map[string]Book
+	nextID int
+}
+
+// NewMemoryStore returns an empty, ready-to-use MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		books:  make(map[string]Book),
+		nextID: 1,
+	}
+}
+
+func (s *MemoryStore) Create(book Book) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book.ID = strconv.Itoa(s.nextID)
+	s.nextID++
+	s.books[book.ID] = book
+	return book, nil
+}
+
+func (s *MemoryStore) Get(id string) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	book, found := s.books[id]
+	if !found {
+		return Book{}, ErrNotFound
+	}
+	return book, nil
+}
+
+func (s *MemoryStore) List(opts ListOptions) ([]Book, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	books := make([]Book, 0, len(s.books))
+	for _, book := range s.books {
+		if opts.Author != "" && !containsFold(book.Author, opts.Author) {
+			continue
+		}
+		if opts.Title != "" && !containsFold(book.Title, opts.Title) {
+			continue
+		}
+		books = append(books, book)
+	}
+
+	sortBooks(books, opts.Sort, opts.Order)
+	total := len(books)
+
+	if opts.Offset > 0 {
+		if opts.Offset >= len(books) {
+			books = nil
+		} else {
+			books = books[opts.Offset:]
+		}
+	}
+	if opts.Limit > 0 && opts.Limit < len(books) {
+		books = books[:opts.Limit]
+	}
+
+	return books, total, nil
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// sortBooks orders books in place by field, using order ("asc"/"desc",
+// default "asc"). An unrecognized field sorts by ID.
+func sortBooks(books []Book, field, order string) {
+	desc := order == OrderDesc
+
+	less := func(i, j int) bool {
+		var result bool
+		switch field {
+		case SortTitle:
+			result = strings.ToLower(books[i].Title) < strings.ToLower(books[j].Title)
+		case SortAuthor:
+			result = strings.ToLower(books[i].Author) < strings.ToLower(books[j].Author)
+		default:
+			result = idLess(books[i].ID, books[j].ID)
+		}
+		if desc {
+			return !result
+		}
+		return result
+	}
+	sort.SliceStable(books, less)
+}
+
+// idLess compares IDs numerically when both parse as integers (the
+// MemoryStore case), falling back to a lexical compare otherwise (Mongo
+// ObjectID hex strings, whose lexical order tracks insertion time).
+func idLess(a, b string) bool {
+	ai, aerr := strconv.Atoi(a)
+	bi, berr := strconv.Atoi(b)
+	if aerr == nil && berr == nil {
+		return ai < bi
+	}
+	return a < b
+}
+
+func (s *MemoryStore) Update(id string, book Book) (Book, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.books[id]; !found {
+		return Book{}, ErrNotFound
+	}
+	book.ID = id
+	s.books[id] = book
+	return book, nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.books[id]; !found {
+		return ErrNotFound
+	}
+	delete(s.books, id)
+	return nil
+}
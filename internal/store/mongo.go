@@ -0,0 +1,213 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// mongoBook mirrors Book but uses an ObjectID for BSON storage; the public
+// Book type keeps IDs as strings so callers never depend on the driver.
+type mongoBook struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	Title         string             `bson:"title"`
+	Author        string             `bson:"author"`
+	ISBN          string             `bson:"isbn"`
+	Price         float64            `bson:"price"`
+	PublishedYear int                `bson:"publishedYear"`
+	ImageURL      string             `bson:"imageUrl,omitempty"`
+}
+
+func newMongoBook(id primitive.ObjectID, book Book) mongoBook {
+	return mongoBook{
+		ID:            id,
+		Title:         book.Title,
+		Author:        book.Author,
+		ISBN:          book.ISBN,
+		Price:         book.Price,
+		PublishedYear: book.PublishedYear,
+		ImageURL:      book.ImageURL,
+	}
+}
+
+func (b mongoBook) toBook() Book {
+	return Book{
+		ID:            b.ID.Hex(),
+		Title:         b.Title,
+		Author:        b.Author,
+		ISBN:          b.ISBN,
+		Price:         b.Price,
+		PublishedYear: b.PublishedYear,
+		ImageURL:      b.ImageURL,
+	}
+}
+
+// MongoStore is a BookStore backed by a MongoDB collection.
+type MongoStore struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewMongoStore connects to uri and returns a MongoStore backed by
+// database.collection. The returned store owns the underlying client and
+// keeps it connected for its lifetime; MongoStore exposes neither the
+// client nor a method to disconnect it.
+func NewMongoStore(ctx context.Context, uri, database, collection string) (*MongoStore, error) {
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		return nil, err
+	}
+	if err := client.Ping(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	return &MongoStore{
+		collection: client.Database(database).Collection(collection),
+		timeout:    10 * time.Second,
+	}, nil
+}
+
+func (s *MongoStore) Create(book Book) (Book, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	doc := newMongoBook(primitive.NewObjectID(), book)
+	if _, err := s.collection.InsertOne(ctx, doc); err != nil {
+		return Book{}, err
+	}
+	return doc.toBook(), nil
+}
+
+func (s *MongoStore) Get(id string) (Book, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Book{}, ErrNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	var doc mongoBook
+	if err := s.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return Book{}, ErrNotFound
+		}
+		return Book{}, err
+	}
+	return doc.toBook(), nil
+}
+
+func (s *MongoStore) List(opts ListOptions) ([]Book, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	filter := bson.M{}
+	if opts.Author != "" {
+		filter["author"] = bson.M{"$regex": primitive.Regex{Pattern: regexp.QuoteMeta(opts.Author), Options: "i"}}
+	}
+	if opts.Title != "" {
+		filter["title"] = bson.M{"$regex": primitive.Regex{Pattern: regexp.QuoteMeta(opts.Title), Options: "i"}}
+	}
+
+	total, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOpts := options.Find().SetSort(bson.D{{Key: sortField(opts.Sort), Value: sortDirection(opts.Order)}})
+	if opts.Offset > 0 {
+		findOpts.SetSkip(int64(opts.Offset))
+	}
+	if opts.Limit > 0 {
+		findOpts.SetLimit(int64(opts.Limit))
+	}
+
+	cursor, err := s.collection.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	books := make([]Book, 0)
+	for cursor.Next(ctx) {
+		var doc mongoBook
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		books = append(books, doc.toBook())
+	}
+	return books, int(total), cursor.Err()
+}
+
+// sortField maps a ListOptions.Sort value to the BSON field it orders by.
+func sortField(field string) string {
+	switch field {
+	case SortTitle:
+		return "title"
+	case SortAuthor:
+		return "author"
+	default:
+		return "_id"
+	}
+}
+
+func sortDirection(order string) int {
+	if order == OrderDesc {
+		return -1
+	}
+	return 1
+}
+
+func (s *MongoStore) Update(id string, book Book) (Book, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return Book{}, ErrNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{
+		"title":         book.Title,
+		"author":        book.Author,
+		"isbn":          book.ISBN,
+		"price":         book.Price,
+		"publishedYear": book.PublishedYear,
+		"imageUrl":      book.ImageURL,
+	}}
+	res, err := s.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return Book{}, err
+	}
+	if res.MatchedCount == 0 {
+		return Book{}, ErrNotFound
+	}
+
+	book.ID = id
+	return book, nil
+}
+
+func (s *MongoStore) Delete(id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return ErrNotFound
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	res, err := s.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Package store defines the persistence abstraction used by the API
+// handlers, along with the concrete backends that implement it.
+package store
+
+import "errors"
+
+// ErrNotFound is returned by BookStore implementations when a lookup,
+// update, or delete targets an ID that does not exist.
+var ErrNotFound = errors.New("store: book not found")
+
+// Book represents a book resource as persisted by a BookStore. Validation
+// tags are enforced by the API layer before a Book reaches the store.
+type Book struct {
+	ID            string  `json:"id"`
+	Title         string  `json:"title" validate:"required"`
+	Author        string  `json:"author" validate:"required"`
+	ISBN          string  `json:"isbn" validate:"required,isbn"`
+	Price         float64 `json:"price" validate:"gte=0"`
+	PublishedYear int     `json:"publishedYear" validate:"required,gte=1450,lte=2100"`
+	ImageURL      string  `json:"imageUrl,omitempty" validate:"omitempty,url"`
+}
+
+// Sort fields and order values accepted by ListOptions.
+const (
+	SortID     = "id"
+	SortTitle  = "title"
+	SortAuthor = "author"
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// ListOptions controls pagination, filtering and sorting for List. Limit
+// <= 0 means "no limit". Sort and Order default to SortID and OrderAsc
+// when empty. Author and Title filter by case-insensitive substring.
+type ListOptions struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Order  string
+	Author string
+	Title  string
+}
+
+// BookStore is the persistence boundary for books. Implementations must be
+// safe for concurrent use. Create assigns and returns the ID; callers must
+// not rely on any ID set on the input Book. List returns the page of books
+// matching opts along with the total count across all pages (ignoring
+// Limit/Offset).
+type BookStore interface {
+	Create(book Book) (Book, error)
+	Get(id string) (Book, error)
+	List(opts ListOptions) (books []Book, total int, err error)
+	Update(id string, book Book) (Book, error)
+	Delete(id string) error
+}
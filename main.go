@@ -1,28 +1,39 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
 	"strconv"
-	"sync"
-)
+	"strings"
+	"syscall"
+	"time"
 
-// Book represents a simple book structure
-type Book struct {
-	ID     int    `json:"id"`
-	Title  string `json:"title"`
-	Author string `json:"author"`
-}
+	"github.com/prometheus/client_golang/prometheus"
 
-// In-memory book storage and a mutex for concurrent access
-var (
-	books = make(map[int]Book)
-	mu    sync.Mutex
-	nextID = 1
+	"github.com/sojoudian/goTestAPI/internal/metrics"
+	"github.com/sojoudian/goTestAPI/internal/middleware"
+	"github.com/sojoudian/goTestAPI/internal/openapi"
+	"github.com/sojoudian/goTestAPI/internal/router"
+	"github.com/sojoudian/goTestAPI/internal/store"
 )
 
-// Helper to send JSON responses
+const defaultListLimit = 20
+
+// api holds the dependencies shared by the HTTP handlers. Using a struct
+// instead of package-level globals lets handlers be backed by any
+// store.BookStore implementation.
+type api struct {
+	store store.BookStore
+}
+
+// sendJSON writes data as a JSON response with the given status code.
 func sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -30,134 +41,343 @@ func sendJSON(w http.ResponseWriter, data interface{}, statusCode int) {
 }
 
 // CreateBook handles POST /books for creating a new book
-func CreateBook(w http.ResponseWriter, r *http.Request) {
-	var book Book
+func (a *api) CreateBook(w http.ResponseWriter, r *http.Request) {
+	var book store.Book
 	if err := json.NewDecoder(r.Body).Decode(&book); err != nil {
-		http.Error(w, "Invalid input", http.StatusBadRequest)
+		sendError(w, http.StatusBadRequest, "invalid_body", nil)
 		return
 	}
 
-	mu.Lock()
-	book.ID = nextID
-	books[book.ID] = book
-	nextID++
-	mu.Unlock()
+	if err := validate.Struct(book); err != nil {
+		sendError(w, http.StatusBadRequest, "validation_failed", validationFields(err))
+		return
+	}
 
-	sendJSON(w, book, http.StatusCreated)
+	created, err := a.store.Create(book)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, "internal_error", nil)
+		return
+	}
+
+	sendJSON(w, created, http.StatusCreated)
 }
 
-// GetBooks handles GET /books for listing all books
-func GetBooks(w http.ResponseWriter, r *http.Request) {
-	mu.Lock()
-	defer mu.Unlock()
+// page describes the pagination metadata returned alongside a book listing.
+type page struct {
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Total  int    `json:"total"`
+	Next   string `json:"next,omitempty"`
+	Prev   string `json:"prev,omitempty"`
+}
 
-	var bookList []Book
-	for _, book := range books {
-		bookList = append(bookList, book)
+// booksResponse is the envelope returned by GetBooks.
+type booksResponse struct {
+	Data []store.Book `json:"data"`
+	Page page         `json:"page"`
+}
+
+// GetBooks handles GET /books, honoring ?limit=&offset=, ?sort=&order=,
+// and the ?author=&?title= substring filters.
+func (a *api) GetBooks(w http.ResponseWriter, r *http.Request) {
+	opts := listOptionsFromQuery(r.URL.Query())
+
+	books, total, err := a.store.List(opts)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, "internal_error", nil)
+		return
+	}
+
+	pg := page{Limit: opts.Limit, Offset: opts.Offset, Total: total}
+	if opts.Limit > 0 && opts.Offset+opts.Limit < total {
+		pg.Next = paginationURL(r.URL, opts.Offset+opts.Limit, opts.Limit)
 	}
+	if opts.Offset > 0 {
+		prevOffset := opts.Offset - opts.Limit
+		if prevOffset < 0 {
+			prevOffset = 0
+		}
+		pg.Prev = paginationURL(r.URL, prevOffset, opts.Limit)
+	}
+	setLinkHeader(w, pg)
 
-	sendJSON(w, bookList, http.StatusOK)
+	sendJSON(w, booksResponse{Data: books, Page: pg}, http.StatusOK)
+}
+
+// listOptionsFromQuery builds a store.ListOptions from GET /books query
+// parameters, applying defaultListLimit when ?limit is absent.
+func listOptionsFromQuery(q url.Values) store.ListOptions {
+	opts := store.ListOptions{
+		Limit:  defaultListLimit,
+		Sort:   q.Get("sort"),
+		Order:  q.Get("order"),
+		Author: q.Get("author"),
+		Title:  q.Get("title"),
+	}
+	if v, err := strconv.Atoi(q.Get("limit")); err == nil {
+		opts.Limit = v
+	}
+	if v, err := strconv.Atoi(q.Get("offset")); err == nil {
+		opts.Offset = v
+	}
+	return opts
+}
+
+// paginationURL renders u with its offset/limit query params replaced.
+func paginationURL(u *url.URL, offset, limit int) string {
+	next := *u
+	q := next.Query()
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	next.RawQuery = q.Encode()
+	return next.String()
+}
+
+// setLinkHeader adds RFC 8288 Link header entries for the next/prev pages.
+func setLinkHeader(w http.ResponseWriter, pg page) {
+	var links []string
+	if pg.Next != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pg.Next))
+	}
+	if pg.Prev != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pg.Prev))
+	}
+	for _, link := range links {
+		w.Header().Add("Link", link)
+	}
 }
 
 // GetBook handles GET /books/{id} for retrieving a specific book by ID
-func GetBook(w http.ResponseWriter, r *http.Request) {
-	idStr := r.URL.Path[len("/books/"):]
-	id, err := strconv.Atoi(idStr)
+func (a *api) GetBook(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, "id")
+
+	book, err := a.store.Get(id)
 	if err != nil {
-		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		if err == store.ErrNotFound {
+			sendError(w, http.StatusNotFound, "not_found", nil)
+			return
+		}
+		sendError(w, http.StatusInternalServerError, "internal_error", nil)
+		return
+	}
+
+	sendJSON(w, book, http.StatusOK)
+}
+
+// UpdateBook handles PUT /books/{id} for updating an existing book
+func (a *api) UpdateBook(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, "id")
+
+	var updatedBook store.Book
+	if err := json.NewDecoder(r.Body).Decode(&updatedBook); err != nil {
+		sendError(w, http.StatusBadRequest, "invalid_body", nil)
 		return
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
+	if err := validate.Struct(updatedBook); err != nil {
+		sendError(w, http.StatusBadRequest, "validation_failed", validationFields(err))
+		return
+	}
 
-	book, found := books[id]
-	if !found {
-		http.Error(w, "Book not found", http.StatusNotFound)
+	saved, err := a.store.Update(id, updatedBook)
+	if err != nil {
+		if err == store.ErrNotFound {
+			sendError(w, http.StatusNotFound, "not_found", nil)
+			return
+		}
+		sendError(w, http.StatusInternalServerError, "internal_error", nil)
 		return
 	}
 
-	sendJSON(w, book, http.StatusOK)
+	sendJSON(w, saved, http.StatusOK)
 }
 
-// UpdateBook handles PUT /books/{id} for updating an existing book
-func UpdateBook(w http.ResponseWriter, r *http.Request) {
-	idStr := r.URL.Path[len("/books/"):]
-	id, err := strconv.Atoi(idStr)
+// PatchBook handles PATCH /books/{id}, applying an RFC 7396 JSON Merge
+// Patch: only the keys present in the request body are changed, and an
+// explicit null resets a field to its zero value.
+func (a *api) PatchBook(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, "id")
+
+	existing, err := a.store.Get(id)
 	if err != nil {
-		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+		if err == store.ErrNotFound {
+			sendError(w, http.StatusNotFound, "not_found", nil)
+			return
+		}
+		sendError(w, http.StatusInternalServerError, "internal_error", nil)
 		return
 	}
 
-	var updatedBook Book
-	if err := json.NewDecoder(r.Body).Decode(&updatedBook); err != nil {
-		http.Error(w, "Invalid input", http.StatusBadRequest)
+	var patch map[string]json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		sendError(w, http.StatusBadRequest, "invalid_body", nil)
 		return
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
+	merged := existing
+	unknown, err := applyMergePatch(&merged, patch)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, "invalid_body", nil)
+		return
+	}
+	if len(unknown) > 0 {
+		sendError(w, http.StatusBadRequest, "unknown_field", unknown)
+		return
+	}
 
-	_, found := books[id]
-	if !found {
-		http.Error(w, "Book not found", http.StatusNotFound)
+	if err := validate.Struct(merged); err != nil {
+		sendError(w, http.StatusBadRequest, "validation_failed", validationFields(err))
 		return
 	}
 
-	updatedBook.ID = id
-	books[id] = updatedBook
+	saved, err := a.store.Update(id, merged)
+	if err != nil {
+		if err == store.ErrNotFound {
+			sendError(w, http.StatusNotFound, "not_found", nil)
+			return
+		}
+		sendError(w, http.StatusInternalServerError, "internal_error", nil)
+		return
+	}
 
-	sendJSON(w, updatedBook, http.StatusOK)
+	sendJSON(w, saved, http.StatusOK)
 }
 
 // DeleteBook handles DELETE /books/{id} for deleting a book
-func DeleteBook(w http.ResponseWriter, r *http.Request) {
-	idStr := r.URL.Path[len("/books/"):]
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		http.Error(w, "Invalid book ID", http.StatusBadRequest)
+func (a *api) DeleteBook(w http.ResponseWriter, r *http.Request) {
+	id := router.Param(r, "id")
+
+	if err := a.store.Delete(id); err != nil {
+		if err == store.ErrNotFound {
+			sendError(w, http.StatusNotFound, "not_found", nil)
+			return
+		}
+		sendError(w, http.StatusInternalServerError, "internal_error", nil)
 		return
 	}
 
-	mu.Lock()
-	defer mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
 
-	_, found := books[id]
-	if !found {
-		http.Error(w, "Book not found", http.StatusNotFound)
-		return
+// newStore builds the BookStore selected by the STORE_BACKEND env var
+// ("memory" or "mongo"), defaulting to "memory" when unset.
+func newStore(ctx context.Context) (store.BookStore, error) {
+	backend := os.Getenv("STORE_BACKEND")
+	switch backend {
+	case "", "memory":
+		return store.NewMemoryStore(), nil
+	case "mongo":
+		uri := os.Getenv("MONGO_URI")
+		database := os.Getenv("MONGO_DB")
+		collection := os.Getenv("MONGO_COLLECTION")
+		if uri == "" || database == "" || collection == "" {
+			return nil, fmt.Errorf("MONGO_URI, MONGO_DB and MONGO_COLLECTION must all be set when STORE_BACKEND=mongo")
+		}
+		return store.NewMongoStore(ctx, uri, database, collection)
+	default:
+		return nil, fmt.Errorf("unknown STORE_BACKEND %q", backend)
 	}
+}
 
-	delete(books, id)
-	w.WriteHeader(http.StatusNoContent)
+// envDuration reads key as a time.Duration (e.g. "10s"), falling back to
+// def if unset or unparsable.
+func envDuration(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return def
+}
+
+// parseCORSOrigins parses a comma-separated CORS_ALLOWED_ORIGINS value
+// (e.g. "https://a.example,https://b.example", or "*") into the list
+// middleware.CORS checks requests against. An empty value allows no
+// origin, so CORS stays off unless explicitly configured.
+func parseCORSOrigins(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	var origins []string
+	for _, o := range strings.Split(raw, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			origins = append(origins, o)
+		}
+	}
+	return origins
 }
 
 // Route handling for CRUD operations
 func main() {
-	http.HandleFunc("/books", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			GetBooks(w, r)
-		case http.MethodPost:
-			CreateBook(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		}
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	bookStore, err := newStore(context.Background())
+	if err != nil {
+		log.Fatalf("failed to initialize store: %v", err)
+	}
+	a := &api{store: bookStore}
+
+	authChain, err := authMiddlewares()
+	if err != nil {
+		log.Fatalf("failed to configure auth: %v", err)
+	}
+
+	reg := prometheus.NewRegistry()
+	reqMetrics := metrics.New()
+	booksTotal := metrics.BooksTotalGauge(func() (int, error) {
+		_, total, err := bookStore.List(store.ListOptions{Limit: 1})
+		return total, err
 	})
+	reqMetrics.MustRegister(reg, booksTotal)
+
+	global := append([]router.Middleware{
+		middleware.RequestID,
+		middleware.Logging(logger),
+		middleware.Recoverer,
+		middleware.CORS(middleware.CORSConfig{AllowedOrigins: parseCORSOrigins(os.Getenv("CORS_ALLOWED_ORIGINS"))}),
+		reqMetrics.Middleware,
+	}, authChain...)
+	rt := router.New(global...)
+
+	specs := routes(a)
+	register(rt, specs)
+
+	info := openapi.Info{Title: "goTestAPI", Version: "1.0.0"}
+	rt.Handle(http.MethodGet, "/openapi.json", openapi.JSONHandler(info, openAPIRoutes(specs)))
+	rt.Handle(http.MethodGet, "/openapi.yaml", openapi.YAMLHandler(info, openAPIRoutes(specs)))
+	rt.Handle(http.MethodGet, "/docs", openapi.DocsHandler())
+	rt.Handle(http.MethodGet, "/healthz", Healthz)
+	rt.Handle(http.MethodGet, "/readyz", readyzHandler(bookStore))
+	rt.Handle(http.MethodGet, "/metrics", metrics.Handler(reg).ServeHTTP)
+
+	srv := &http.Server{
+		Addr:              ":8080",
+		Handler:           rt,
+		ReadHeaderTimeout: 5 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	http.HandleFunc("/books/", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			GetBook(w, r)
-		case http.MethodPut:
-			UpdateBook(w, r)
-		case http.MethodDelete:
-			DeleteBook(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	go func() {
+		fmt.Println("Server is running on http://localhost:8080")
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("server error: %v", err)
 		}
-	})
+	}()
 
-	fmt.Println("Server is running on http://localhost:8080")
-	http.ListenAndServe(":8080", nil)
+	<-ctx.Done()
+	stop()
+
+	shutdownTimeout := envDuration("SHUTDOWN_TIMEOUT", 10*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Fatalf("graceful shutdown failed: %v", err)
+	}
 }
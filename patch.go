@@ -0,0 +1,62 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sojoudian/goTestAPI/internal/store"
+)
+
+// bookJSONFields maps each store.Book json tag to its struct field index,
+// used by applyMergePatch to know which keys a merge patch may touch.
+func bookJSONFields() map[string]int {
+	t := reflect.TypeOf(store.Book{})
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		fields[tag] = i
+	}
+	return fields
+}
+
+// applyMergePatch applies an RFC 7396 JSON Merge Patch to dst in place:
+// each key in patch overwrites the matching field, an explicit JSON null
+// resets it to its zero value, and "id" may not be patched. It returns
+// the unknown field names in patch, if any; dst is left unmodified for
+// those keys.
+func applyMergePatch(dst *store.Book, patch map[string]json.RawMessage) ([]fieldError, error) {
+	fields := bookJSONFields()
+	v := reflect.ValueOf(dst).Elem()
+
+	var unknown []fieldError
+	for key, raw := range patch {
+		if key == "id" {
+			unknown = append(unknown, fieldError{Field: key, Rule: "immutable"})
+			continue
+		}
+
+		idx, ok := fields[key]
+		if !ok {
+			unknown = append(unknown, fieldError{Field: key, Rule: "unknown_field"})
+			continue
+		}
+
+		fv := v.Field(idx)
+		if string(raw) == "null" {
+			fv.Set(reflect.Zero(fv.Type()))
+			continue
+		}
+
+		ptr := reflect.New(fv.Type())
+		if err := json.Unmarshal(raw, ptr.Interface()); err != nil {
+			return nil, fmt.Errorf("field %q: %w", key, err)
+		}
+		fv.Set(ptr.Elem())
+	}
+	return unknown, nil
+}
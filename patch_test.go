@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sojoudian/goTestAPI/internal/router"
+	"github.com/sojoudian/goTestAPI/internal/store"
+)
+
+func newTestAPI(t *testing.T) (*api, store.Book) {
+	t.Helper()
+
+	s := store.NewMemoryStore()
+	seed, err := s.Create(store.Book{
+		Title:         "Original Title",
+		Author:        "Original Author",
+		ISBN:          "978-3-16-148410-0",
+		Price:         9.99,
+		PublishedYear: 2000,
+	})
+	if err != nil {
+		t.Fatalf("seed book: %v", err)
+	}
+	return &api{store: s}, seed
+}
+
+func patchRequest(t *testing.T, a *api, id string, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rt := router.New()
+	rt.Handle(http.MethodPatch, "/books/{id}", a.PatchBook)
+
+	req := httptest.NewRequest(http.MethodPatch, "/books/"+id, bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	rt.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPatchBookPartialTitleUpdate(t *testing.T) {
+	a, seed := newTestAPI(t)
+
+	rec := patchRequest(t, a, seed.ID, `{"title":"New Title"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got store.Book
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Title != "New Title" {
+		t.Errorf("Title = %q, want %q", got.Title, "New Title")
+	}
+	if got.Author != seed.Author {
+		t.Errorf("Author changed to %q, want unchanged %q", got.Author, seed.Author)
+	}
+}
+
+func TestPatchBookNoop(t *testing.T) {
+	a, seed := newTestAPI(t)
+
+	rec := patchRequest(t, a, seed.ID, `{}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got store.Book
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got != seed {
+		t.Errorf("no-op patch changed book: got %+v, want %+v", got, seed)
+	}
+}
+
+func TestPatchBookNullResetsField(t *testing.T) {
+	a, seed := newTestAPI(t)
+	seed.ImageURL = "https://example.com/cover.jpg"
+	if _, err := a.store.Update(seed.ID, seed); err != nil {
+		t.Fatalf("set imageUrl: %v", err)
+	}
+
+	rec := patchRequest(t, a, seed.ID, `{"imageUrl":null}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got store.Book
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.ImageURL != "" {
+		t.Errorf("ImageURL = %q, want zero value", got.ImageURL)
+	}
+}
+
+func TestPatchBookUnknownField(t *testing.T) {
+	a, seed := newTestAPI(t)
+
+	rec := patchRequest(t, a, seed.ID, `{"publisher":"Acme"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/sojoudian/goTestAPI/internal/openapi"
+	"github.com/sojoudian/goTestAPI/internal/router"
+	"github.com/sojoudian/goTestAPI/internal/store"
+)
+
+// routeSpec is the single source of truth for a route: it carries enough
+// to both register the handler with the router and describe the route in
+// the generated OpenAPI document, so the two can't drift apart.
+type routeSpec struct {
+	Method       string
+	Pattern      string
+	Handler      http.HandlerFunc
+	Summary      string
+	RequestType  reflect.Type
+	ResponseType reflect.Type
+	AuthRequired bool
+}
+
+// routes builds the route table for a given api. The book handlers are
+// bound methods on a, so those routeSpecs close over this particular
+// instance; WhoAmI is a plain function.
+func routes(a *api) []routeSpec {
+	return []routeSpec{
+		{
+			Method: http.MethodGet, Pattern: "/books", Handler: a.GetBooks,
+			Summary: "List books", ResponseType: reflect.TypeOf(booksResponse{}),
+		},
+		{
+			Method: http.MethodPost, Pattern: "/books", Handler: a.CreateBook,
+			Summary: "Create a book", AuthRequired: true,
+			RequestType: reflect.TypeOf(store.Book{}), ResponseType: reflect.TypeOf(store.Book{}),
+		},
+		{
+			Method: http.MethodGet, Pattern: "/books/{id}", Handler: a.GetBook,
+			Summary: "Get a book by ID", ResponseType: reflect.TypeOf(store.Book{}),
+		},
+		{
+			Method: http.MethodPut, Pattern: "/books/{id}", Handler: a.UpdateBook,
+			Summary: "Replace a book", AuthRequired: true,
+			RequestType: reflect.TypeOf(store.Book{}), ResponseType: reflect.TypeOf(store.Book{}),
+		},
+		{
+			Method: http.MethodPatch, Pattern: "/books/{id}", Handler: a.PatchBook,
+			Summary: "Partially update a book (JSON Merge Patch)", AuthRequired: true,
+			ResponseType: reflect.TypeOf(store.Book{}),
+		},
+		{
+			Method: http.MethodDelete, Pattern: "/books/{id}", Handler: a.DeleteBook,
+			Summary: "Delete a book", AuthRequired: true,
+		},
+		{
+			Method: http.MethodGet, Pattern: "/auth/whoami", Handler: WhoAmI,
+			Summary: "Report the caller's authenticated identity, if any",
+			ResponseType: reflect.TypeOf(whoAmIResponse{}),
+		},
+	}
+}
+
+// register binds every routeSpec to rt.
+func register(rt *router.Router, specs []routeSpec) {
+	for _, s := range specs {
+		rt.Handle(s.Method, s.Pattern, s.Handler)
+	}
+}
+
+// openAPIRoutes converts routeSpecs into the shape the openapi package
+// generates a spec from.
+func openAPIRoutes(specs []routeSpec) []openapi.Route {
+	oaRoutes := make([]openapi.Route, 0, len(specs))
+	for _, s := range specs {
+		oaRoutes = append(oaRoutes, openapi.Route{
+			Method:       s.Method,
+			Pattern:      s.Pattern,
+			Summary:      s.Summary,
+			RequestType:  s.RequestType,
+			ResponseType: s.ResponseType,
+			AuthRequired: s.AuthRequired,
+		})
+	}
+	return oaRoutes
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/sojoudian/goTestAPI/internal/auth"
+)
+
+// whoAmIResponse is the body returned by GET /auth/whoami.
+type whoAmIResponse struct {
+	Authenticated bool   `json:"authenticated"`
+	Subject       string `json:"subject,omitempty"`
+	Method        string `json:"method,omitempty"`
+}
+
+// WhoAmI handles GET /auth/whoami, reporting the identity (if any) that
+// the auth middlewares attached to the request context.
+func WhoAmI(w http.ResponseWriter, r *http.Request) {
+	id, ok := auth.FromContext(r.Context())
+	if !ok {
+		sendJSON(w, whoAmIResponse{Authenticated: false}, http.StatusOK)
+		return
+	}
+
+	sendJSON(w, whoAmIResponse{
+		Authenticated: true,
+		Subject:       id.Subject,
+		Method:        id.Method,
+	}, http.StatusOK)
+}